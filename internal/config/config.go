@@ -3,6 +3,7 @@ package config
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
@@ -31,6 +32,10 @@ type Graph struct {
 	QueryExpression string      `json:"queryExpression" mapstructure:"QUERY_EXPRESSION"`
 	YAxisUnit       string      `json:"yAxisUnit" mapstructure:"Y_AXIS_UNIT"`
 	ValueRounding   int         `json:"valueRounding" mapstructure:"VALUE_ROUNDING"`
+	// ProviderType overrides the parent Dashboard's ProviderType for this
+	// single graph, so a dashboard can mix panels sourced from different
+	// metrics providers. Empty falls back to the Dashboard's ProviderType.
+	ProviderType string `json:"providerType" mapstructure:"PROVIDER_TYPE"`
 }
 
 type Row struct {
@@ -84,16 +89,71 @@ func (a Application) GetDashBoard(groupKind string) *Dashboard {
 	return a.DefaultDashboard
 }
 
-type provider struct {
-	Name      string           `json:"name" mapstructure:"NAME"`
-	Address   string           `json:"address" mapstructure:"ADDRESS"`
-	Default   bool             `json:"default" mapstructure:"DEFAULT"`
-	TLSConfig config.TLSConfig `json:"TLSConfig" mapstructure:"TLS_CONFIG"`
+// Supported provider.AuthMode values.
+const (
+	AuthModeNone                  = "none"
+	AuthModeBasic                 = "basic"
+	AuthModeBearer                = "bearer"
+	AuthModeAzureManagedIdentity  = "azure-managed-identity"
+	AuthModeAzureWorkloadIdentity = "azure-workload-identity"
+)
+
+// DefaultAzureResource is the AAD resource/scope requested for Azure
+// Managed Prometheus when AzureAuth.Resource is left unset.
+const DefaultAzureResource = "https://prometheus.monitor.azure.com/.default"
+
+// AzureAuth configures acquiring an AAD bearer token for Azure Managed
+// Prometheus, either via managed identity (IMDS) or workload identity
+// (a projected federated token file exchanged with AAD).
+type AzureAuth struct {
+	ClientID  string `json:"clientId" mapstructure:"CLIENT_ID"`
+	TenantID  string `json:"tenantId" mapstructure:"TENANT_ID"`
+	Resource  string `json:"resource" mapstructure:"RESOURCE"`
+	TokenFile string `json:"tokenFile" mapstructure:"TOKEN_FILE"`
+}
+
+// ProviderConfig is exported (rather than the package-private type it
+// started as) so internal/provider can take it by value and branch on
+// AuthMode/Azure when building the provider's real *http.Client, without
+// internal/config importing internal/provider and creating a cycle.
+type ProviderConfig struct {
+	Name    string `json:"name" mapstructure:"NAME"`
+	Address string `json:"address" mapstructure:"ADDRESS"`
+	Default bool   `json:"default" mapstructure:"DEFAULT"`
+
+	// HTTPClientConfig carries TLS, basic auth, bearer token and OAuth2
+	// settings for securing requests to the provider's API, e.g. a
+	// Prometheus/Thanos endpoint sitting behind mTLS or a reverse proxy.
+	// Its nested BasicAuth/Authorization/OAuth2 blocks accept *_file
+	// variants (password_file, credentials_file, client_secret_file) so
+	// credentials can be projected from a Secret without landing in the
+	// config JSON itself. These nested settings are read from the config
+	// JSON only; environment variables do not override them.
+	HTTPClientConfig config.HTTPClientConfig `json:"httpClientConfig" mapstructure:"HTTP_CLIENT_CONFIG"`
+
+	// AuthMode selects how requests to this provider are authenticated:
+	// "none", "basic"/"bearer" (handled by HTTPClientConfig above), or one
+	// of the Azure AAD modes, which require Azure to be set. Azure modes
+	// are not handled by NewHTTPClient below — see internal/provider's
+	// NewHTTPClient, which wraps this one with AAD bearer-token injection.
+	AuthMode string    `json:"authMode" mapstructure:"AUTH_MODE"`
+	Azure    AzureAuth `json:"azure" mapstructure:"AZURE"`
+}
+
+// NewHTTPClient builds an *http.Client for this provider honoring its
+// HTTPClientConfig: CA/cert/key files, InsecureSkipVerify, basic auth,
+// bearer tokens and OAuth2. TLS certificate and credential files are
+// re-read from disk by the underlying round tripper on each request, so
+// rotating a mounted cert or token Secret does not require a pod restart.
+// It does not look at AuthMode/Azure; callers wanting Azure AAD auth must
+// wrap the result, e.g. with internal/provider's NewHTTPClient.
+func (p *ProviderConfig) NewHTTPClient() (*http.Client, error) {
+	return config.NewClientFromConfig(p.HTTPClientConfig, p.Name)
 }
 
 type MetricsConfigProvider struct {
-	Applications []Application `json:"applications" mapstructure:"APPLICATIONS"`
-	Provider     provider      `json:"provider" mapstructure:"PROVIDER"`
+	Applications []Application  `json:"applications" mapstructure:"APPLICATIONS"`
+	Provider     ProviderConfig `json:"provider" mapstructure:"PROVIDER"`
 }
 
 func (p *MetricsConfigProvider) GetApp(name string) *Application {
@@ -112,6 +172,26 @@ func (p *MetricsConfigProvider) GetApp(name string) *Application {
 type O11yConfig struct {
 	Prometheus *MetricsConfigProvider `json:"prometheus" mapstructure:"PROMETHEUS"`
 	Wavefront  *MetricsConfigProvider `json:"wavefront" mapstructure:"WAVEFRONT"`
+
+	// Version is a monotonically increasing counter set by ConfigWatcher
+	// each time this snapshot is (re)loaded. It is not read from JSON; it
+	// exists purely for debugging and /healthz-style introspection of
+	// which reload a running pod is currently serving.
+	Version uint64 `json:"-" mapstructure:"-"`
+}
+
+// LoadOption configures optional LoadConfigs behavior.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	strict bool
+}
+
+// WithStrictValidation makes LoadConfigs run (O11yConfig).Validate() after
+// parsing and return its error instead of silently accepting a config with
+// structural problems (bad graphType, unknown tab, invalid PromQL, ...).
+func WithStrictValidation() LoadOption {
+	return func(o *loadOptions) { o.strict = true }
 }
 
 // LoadConfigs loads configuration using a two-step process:
@@ -119,7 +199,12 @@ type O11yConfig struct {
 // 2. Then, apply environment variable overrides using mapstructure struct tags
 // This allows the JSON file to be the primary source of configuration with
 // environment variables providing runtime overrides for deployment flexibility.
-func LoadConfigs(logger *zap.SugaredLogger, defaultConf []byte) (O11yConfig, error) {
+func LoadConfigs(logger *zap.SugaredLogger, defaultConf []byte, opts ...LoadOption) (O11yConfig, error) {
+	options := loadOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	conf := O11yConfig{}
 
 	// Step 1: Parse JSON file using JSON tags
@@ -167,5 +252,12 @@ func LoadConfigs(logger *zap.SugaredLogger, defaultConf []byte) (O11yConfig, err
 		return conf, err
 	}
 
+	if options.strict {
+		if err := conf.Validate(); err != nil {
+			logger.Error("config failed strict validation", zap.Error(err))
+			return conf, err
+		}
+	}
+
 	return conf, nil
 }
@@ -249,6 +249,16 @@ func TestRow_GetGraph(t *testing.T) {
 	assert.Nil(t, nonExistentGraph)
 }
 
+func TestProvider_NewHTTPClient(t *testing.T) {
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigs(logger, []byte(testConfigJSON))
+	require.NoError(t, err)
+
+	client, err := config.Prometheus.Provider.NewHTTPClient()
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+}
+
 func TestLoadConfigs_InvalidJSON(t *testing.T) {
 	logger := zap.NewExample().Sugar()
 	
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+// deleteSentinel marks a keyed overlay element (identified by its merge
+// key — name, or groupKind for dashboards) for removal from the merged
+// result instead of being merged into the base element of the same key.
+const deleteSentinel = "!delete"
+
+// mergeKeysByField names the field used to identify elements of the array
+// found under that JSON key, so an overlay file can patch (or delete) a
+// single named element instead of replacing the whole array.
+var mergeKeysByField = map[string]string{
+	"applications": "name",
+	"dashboards":   "groupKind",
+	"rows":         "name",
+	"graphs":       "name",
+}
+
+// LoadConfigsFromDir reads every *.json file in dir in lexical order and
+// deep-merges them into a single O11yConfig: later files override earlier
+// ones at the leaf level, while the Application/Dashboard/Row/Graph arrays
+// are merged by their natural key (name, or groupKind for dashboards)
+// instead of being replaced wholesale. This lets a base config shipped
+// with the operator be overlaid with per-application ConfigMaps dropped
+// into a directory (e.g. /etc/o11y/conf.d/*.json) without editing a
+// single monolithic file. An overlay element carrying `"!delete": true`
+// removes the corresponding inherited element instead of merging into it.
+func LoadConfigsFromDir(logger *zap.SugaredLogger, dir string) (O11yConfig, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return O11yConfig{}, fmt.Errorf("listing config files in %s: %w", dir, err)
+	}
+	sort.Strings(files)
+
+	if len(files) == 0 {
+		return O11yConfig{}, fmt.Errorf("no *.json config files found in %s", dir)
+	}
+
+	var merged interface{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return O11yConfig{}, fmt.Errorf("reading %s: %w", file, err)
+		}
+
+		var layer interface{}
+		if err := json.Unmarshal(data, &layer); err != nil {
+			return O11yConfig{}, fmt.Errorf("parsing %s: %w", file, err)
+		}
+
+		merged = mergeJSON(merged, layer)
+	}
+
+	combined, err := json.Marshal(merged)
+	if err != nil {
+		return O11yConfig{}, fmt.Errorf("re-marshaling merged config: %w", err)
+	}
+
+	return LoadConfigs(logger, combined, WithStrictValidation())
+}
+
+// mergeJSON deep-merges overlay onto base. Maps merge key by key; arrays
+// found under a key in mergeKeysByField are merged element-by-element by
+// that key; everything else (scalars, mismatched types, plain arrays) is
+// replaced outright by overlay.
+func mergeJSON(base, overlay interface{}) interface{} {
+	if base == nil {
+		return overlay
+	}
+	if overlay == nil {
+		return base
+	}
+
+	baseMap, baseIsMap := base.(map[string]interface{})
+	overlayMap, overlayIsMap := overlay.(map[string]interface{})
+	if baseIsMap && overlayIsMap {
+		return mergeMaps(baseMap, overlayMap)
+	}
+
+	return overlay
+}
+
+func mergeMaps(base, overlay map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(base))
+	for k, v := range base {
+		result[k] = v
+	}
+
+	for key, overlayVal := range overlay {
+		baseVal, exists := result[key]
+		if !exists {
+			result[key] = overlayVal
+			continue
+		}
+
+		if mergeKey, ok := mergeKeysByField[key]; ok {
+			baseArr, baseIsArr := baseVal.([]interface{})
+			overlayArr, overlayIsArr := overlayVal.([]interface{})
+			if baseIsArr && overlayIsArr {
+				result[key] = mergeKeyedArrays(baseArr, overlayArr, mergeKey)
+				continue
+			}
+		}
+
+		result[key] = mergeJSON(baseVal, overlayVal)
+	}
+
+	return result
+}
+
+// mergeKeyedArrays merges overlay onto base, matching elements by the
+// value of mergeKey. Overlay elements with no matching key are appended;
+// matched elements are deep-merged; an overlay element carrying
+// `"!delete": true` removes the matching base element instead.
+func mergeKeyedArrays(base, overlay []interface{}, mergeKey string) []interface{} {
+	result := make([]interface{}, len(base))
+	copy(result, base)
+
+	index := make(map[interface{}]int, len(result))
+	for i, elem := range result {
+		if m, ok := elem.(map[string]interface{}); ok {
+			index[m[mergeKey]] = i
+		}
+	}
+
+	deleted := make(map[int]bool)
+	for _, overlayElem := range overlay {
+		overlayMap, ok := overlayElem.(map[string]interface{})
+		if !ok {
+			result = append(result, overlayElem)
+			continue
+		}
+
+		key := overlayMap[mergeKey]
+		i, exists := index[key]
+
+		if isDeleteMarker(overlayMap) {
+			if exists {
+				deleted[i] = true
+			}
+			continue
+		}
+
+		if exists {
+			result[i] = mergeMaps(result[i].(map[string]interface{}), overlayMap)
+		} else {
+			index[key] = len(result)
+			result = append(result, overlayMap)
+		}
+	}
+
+	if len(deleted) == 0 {
+		return result
+	}
+
+	filtered := make([]interface{}, 0, len(result)-len(deleted))
+	for i, elem := range result {
+		if !deleted[i] {
+			filtered = append(filtered, elem)
+		}
+	}
+	return filtered
+}
+
+func isDeleteMarker(m map[string]interface{}) bool {
+	deleteVal, ok := m[deleteSentinel]
+	if !ok {
+		return false
+	}
+	b, _ := deleteVal.(bool)
+	return b
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const baseDirConfigJSON = `{
+  "prometheus": {
+    "applications": [
+      {
+        "name": "default",
+        "default": true,
+        "dashboards": [
+          {
+            "groupKind": "pod",
+            "tabs": ["GoldenSignal"],
+            "rows": [
+              {
+                "name": "container",
+                "title": "Containers",
+                "tab": "GoldenSignal",
+                "graphs": [
+                  {
+                    "name": "container_cpu_line",
+                    "title": "CPU",
+                    "graphType": "line",
+                    "queryExpression": "sum(rate(container_cpu_usage_seconds_total[5m]))"
+                  }
+                ]
+              }
+            ]
+          }
+        ]
+      }
+    ],
+    "provider": {
+      "name": "default",
+      "address": "http://prometheus-service.monitoring.svc.cluster.local:8080"
+    }
+  }
+}`
+
+const overlayAddsRowJSON = `{
+  "prometheus": {
+    "applications": [
+      {
+        "name": "default",
+        "dashboards": [
+          {
+            "groupKind": "pod",
+            "rows": [
+              {
+                "name": "memory",
+                "title": "Memory",
+                "tab": "GoldenSignal",
+                "graphs": [
+                  {
+                    "name": "container_memory_line",
+                    "graphType": "line",
+                    "queryExpression": "sum(container_memory_working_set_bytes)"
+                  }
+                ]
+              }
+            ]
+          }
+        ]
+      }
+    ]
+  }
+}`
+
+func writeDirConfigs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestLoadConfigsFromDir_AppTeamOverlayAddsRow(t *testing.T) {
+	dir := writeDirConfigs(t, map[string]string{
+		"00-base.json":    baseDirConfigJSON,
+		"10-overlay.json": overlayAddsRowJSON,
+	})
+
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigsFromDir(logger, dir)
+	require.NoError(t, err)
+
+	require.Len(t, config.Prometheus.Applications, 1)
+	app := config.Prometheus.Applications[0]
+	assert.Equal(t, "default", app.Name)
+	assert.True(t, app.Default, "overlay should not have clobbered the inherited default flag")
+
+	require.Len(t, app.Dashboards, 1)
+	dash := app.Dashboards[0]
+	require.Len(t, dash.Rows, 2, "overlay row should be added alongside the inherited one, not replace it")
+
+	row := dash.GetRow("memory")
+	require.NotNil(t, row)
+	assert.Equal(t, "Memory", row.Title)
+
+	inherited := dash.GetRow("container")
+	require.NotNil(t, inherited, "inherited row should survive the overlay untouched")
+	assert.Equal(t, "Containers", inherited.Title)
+}
+
+func TestLoadConfigsFromDir_OverlayDeletesGraph(t *testing.T) {
+	overlayDeletesGraph := `{
+	  "prometheus": {
+	    "applications": [
+	      {
+	        "name": "default",
+	        "dashboards": [
+	          {
+	            "groupKind": "pod",
+	            "rows": [
+	              {
+	                "name": "container",
+	                "graphs": [
+	                  {"name": "container_cpu_line", "!delete": true}
+	                ]
+	              }
+	            ]
+	          }
+	        ]
+	      }
+	    ]
+	  }
+	}`
+
+	dir := writeDirConfigs(t, map[string]string{
+		"00-base.json":    baseDirConfigJSON,
+		"10-overlay.json": overlayDeletesGraph,
+	})
+
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigsFromDir(logger, dir)
+	require.NoError(t, err)
+
+	row := config.Prometheus.Applications[0].Dashboards[0].GetRow("container")
+	require.NotNil(t, row)
+	assert.Empty(t, row.Graphs)
+}
+
+func TestLoadConfigsFromDir_NoFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	logger := zap.NewExample().Sugar()
+	_, err := LoadConfigsFromDir(logger, dir)
+	assert.Error(t, err)
+}
+
+func TestLoadConfigsFromDir_RejectsStructurallyBrokenMergeResult(t *testing.T) {
+	overlayBreaksQueryExpression := `{
+	  "prometheus": {
+	    "applications": [
+	      {
+	        "name": "default",
+	        "dashboards": [
+	          {
+	            "groupKind": "pod",
+	            "rows": [
+	              {
+	                "name": "container",
+	                "graphs": [
+	                  {"name": "container_cpu_line", "queryExpression": ""}
+	                ]
+	              }
+	            ]
+	          }
+	        ]
+	      }
+	    ]
+	  }
+	}`
+
+	dir := writeDirConfigs(t, map[string]string{
+		"00-base.json":    baseDirConfigJSON,
+		"10-overlay.json": overlayBreaksQueryExpression,
+	})
+
+	logger := zap.NewExample().Sugar()
+	_, err := LoadConfigsFromDir(logger, dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "queryExpression must not be empty")
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+var validGraphTypes = map[string]bool{
+	"line":       true,
+	"area":       true,
+	"bar":        true,
+	"scatter":    true,
+	"singlestat": true,
+}
+
+// knownTemplateVars are the template variables the extension injects into
+// QueryExpression at render time. Validate substitutes each with a
+// syntactically valid placeholder before parsing, so a legitimate
+// "{{.namespace}}" isn't mistaken for a PromQL syntax error.
+var knownTemplateVars = map[string]string{
+	"{{.namespace}}": "namespace",
+	"{{.name}}":      "name",
+}
+
+// ValidationError is a single structural problem found by
+// (O11yConfig).Validate, tagged with a JSONPath-style location, e.g.
+// "prometheus.applications[0].dashboards[0].rows[1].graphs[0].queryExpression".
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors aggregates every ValidationError found while walking an
+// O11yConfig, so a user fixing their config sees every problem at once
+// instead of one failure per reload attempt.
+type ValidationErrors struct {
+	Errors []ValidationError
+}
+
+func (e *ValidationErrors) add(path, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (e *ValidationErrors) Error() string {
+	lines := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "; ")
+}
+
+// backendPrometheus and backendWavefront identify which query language a
+// Graph.QueryExpression is written in, so Validate can parse it with the
+// right grammar instead of assuming every backend speaks PromQL. These
+// match provider.TypePrometheus/provider.TypeWavefront, the values
+// Dashboard.ProviderType/Graph.ProviderType are expected to hold.
+const (
+	backendPrometheus = "prometheus"
+	backendWavefront  = "wavefront"
+)
+
+// resolveBackend applies the same precedence provider.Router.For uses to
+// pick a backend for a Dashboard/Graph: an explicit providerType wins,
+// lowercased; an unset one falls back to whatever backend the caller is
+// already validating under (the enclosing Graph's Dashboard, or the
+// O11yConfig section — "prometheus"/"wavefront" — at the top of the walk).
+func resolveBackend(providerType, fallback string) string {
+	if providerType == "" {
+		return fallback
+	}
+	return strings.ToLower(providerType)
+}
+
+// Validate walks the full config tree and returns every structural problem
+// found, aggregated into a *ValidationErrors. A nil return means the
+// config is safe to serve.
+func (c *O11yConfig) Validate() error {
+	errs := &ValidationErrors{}
+
+	if c.Prometheus != nil {
+		c.Prometheus.validate("prometheus", backendPrometheus, errs)
+	}
+	if c.Wavefront != nil {
+		c.Wavefront.validate("wavefront", backendWavefront, errs)
+	}
+
+	if len(errs.Errors) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (p *MetricsConfigProvider) validate(path, backend string, errs *ValidationErrors) {
+	for i, app := range p.Applications {
+		app.validate(fmt.Sprintf("%s.applications[%d]", path, i), backend, errs)
+	}
+}
+
+func (a Application) validate(path, backend string, errs *ValidationErrors) {
+	if a.Name == "" {
+		errs.add(path+".name", "application name must not be empty")
+	}
+
+	for i, dash := range a.Dashboards {
+		if dash == nil {
+			continue
+		}
+		dash.validate(fmt.Sprintf("%s.dashboards[%d]", path, i), backend, errs)
+	}
+
+	if a.DefaultDashboard != nil {
+		a.DefaultDashboard.validate(path+".defaultDashboard", backend, errs)
+	}
+}
+
+func (d *Dashboard) validate(path, sectionBackend string, errs *ValidationErrors) {
+	// Dashboard.ProviderType overrides the section it's nested under, same
+	// as provider.Router.For honors at query time.
+	backend := resolveBackend(d.ProviderType, sectionBackend)
+
+	tabs := make(map[string]bool, len(d.Tabs))
+	for _, tab := range d.Tabs {
+		tabs[tab] = true
+	}
+
+	seenRows := make(map[string]bool, len(d.Rows))
+	for i, row := range d.Rows {
+		if row == nil {
+			continue
+		}
+		rowPath := fmt.Sprintf("%s.rows[%d]", path, i)
+
+		if row.Name != "" {
+			if seenRows[row.Name] {
+				errs.add(rowPath+".name", "duplicate row name %q", row.Name)
+			}
+			seenRows[row.Name] = true
+		}
+
+		if row.Tab != "" && len(d.Tabs) > 0 && !tabs[row.Tab] {
+			errs.add(rowPath+".tab", "tab %q is not listed in dashboard tabs %v", row.Tab, d.Tabs)
+		}
+
+		row.validate(rowPath, backend, errs)
+	}
+}
+
+func (r *Row) validate(path, dashboardBackend string, errs *ValidationErrors) {
+	seenGraphs := make(map[string]bool, len(r.Graphs))
+	for i, graph := range r.Graphs {
+		if graph == nil {
+			continue
+		}
+		graphPath := fmt.Sprintf("%s.graphs[%d]", path, i)
+
+		if graph.Name != "" {
+			if seenGraphs[graph.Name] {
+				errs.add(graphPath+".name", "duplicate graph name %q", graph.Name)
+			}
+			seenGraphs[graph.Name] = true
+		}
+
+		graph.validate(graphPath, dashboardBackend, errs)
+	}
+}
+
+func (g *Graph) validate(path, dashboardBackend string, errs *ValidationErrors) {
+	if g.GraphType != "" && !validGraphTypes[g.GraphType] {
+		errs.add(path+".graphType", "unknown graphType %q", g.GraphType)
+	}
+
+	if strings.TrimSpace(g.QueryExpression) == "" {
+		errs.add(path+".queryExpression", "queryExpression must not be empty")
+		return
+	}
+
+	// Graph.ProviderType overrides its Dashboard's, same precedence
+	// provider.Router.For uses to dispatch the query at runtime — so a
+	// graph validates against whichever grammar it will actually be sent
+	// to, not just the section it happens to be nested under.
+	backend := resolveBackend(g.ProviderType, dashboardBackend)
+
+	// Wavefront dashboards author WQL, not PromQL (see wavefront.go) — a
+	// different grammar this package has no parser for, so only sanity-
+	// check that something was written rather than mis-parsing it as
+	// PromQL and rejecting every legitimate Wavefront query.
+	if backend == backendWavefront {
+		return
+	}
+
+	expr := g.QueryExpression
+	for tmpl, placeholder := range knownTemplateVars {
+		expr = strings.ReplaceAll(expr, tmpl, placeholder)
+	}
+
+	if _, err := parser.ParseExpr(expr); err != nil {
+		errs.add(path+".queryExpression", "invalid PromQL expression: %s", err)
+	}
+}
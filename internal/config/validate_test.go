@@ -0,0 +1,230 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestO11yConfig_Validate_Valid(t *testing.T) {
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigs(logger, []byte(testConfigJSON))
+	require.NoError(t, err)
+
+	assert.NoError(t, config.Validate())
+}
+
+func TestO11yConfig_Validate_WavefrontGraphsAreNotParsedAsPromQL(t *testing.T) {
+	// ts(...) is valid WQL but not valid PromQL; a Wavefront graph using
+	// it must not be rejected by the PromQL parser.
+	wavefrontConfigJSON := `{
+	  "wavefront": {
+	    "applications": [
+	      {
+	        "name": "default",
+	        "default": true,
+	        "dashboards": [
+	          {
+	            "groupKind": "pod",
+	            "tabs": ["GoldenSignal"],
+	            "rows": [
+	              {
+	                "name": "container",
+	                "tab": "GoldenSignal",
+	                "graphs": [
+	                  {
+	                    "name": "container_cpu",
+	                    "graphType": "line",
+	                    "queryExpression": "ts(\"container.cpu.usage\", namespace=\"{{.namespace}}\")"
+	                  }
+	                ]
+	              }
+	            ]
+	          }
+	        ]
+	      }
+	    ],
+	    "provider": {
+	      "name": "default",
+	      "address": "https://example.wavefront.com"
+	    }
+	  }
+	}`
+
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigs(logger, []byte(wavefrontConfigJSON))
+	require.NoError(t, err)
+
+	assert.NoError(t, config.Validate())
+}
+
+func TestO11yConfig_Validate_GraphProviderTypeOverridesSection(t *testing.T) {
+	// A graph living under the "wavefront" section but overridden to
+	// providerType "prometheus" is routed to Prometheus at query time
+	// (see provider.Router.For), so Validate must PromQL-parse it too —
+	// and catch the broken expression below instead of waving it through
+	// because it's nested under "wavefront".
+	wavefrontConfigJSON := `{
+	  "wavefront": {
+	    "applications": [
+	      {
+	        "name": "default",
+	        "default": true,
+	        "dashboards": [
+	          {
+	            "groupKind": "pod",
+	            "tabs": ["GoldenSignal"],
+	            "rows": [
+	              {
+	                "name": "container",
+	                "tab": "GoldenSignal",
+	                "graphs": [
+	                  {
+	                    "name": "container_cpu",
+	                    "graphType": "line",
+	                    "providerType": "prometheus",
+	                    "queryExpression": "sum(("
+	                  }
+	                ]
+	              }
+	            ]
+	          }
+	        ]
+	      }
+	    ],
+	    "provider": {
+	      "name": "default",
+	      "address": "https://example.wavefront.com"
+	    }
+	  }
+	}`
+
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigs(logger, []byte(wavefrontConfigJSON))
+	require.NoError(t, err)
+
+	err = config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid PromQL expression")
+}
+
+func TestO11yConfig_Validate_DashboardProviderTypeOverridesSection(t *testing.T) {
+	// A dashboard living under "prometheus" but overridden to providerType
+	// "wavefront" is routed to Wavefront at query time, so its graphs
+	// author WQL and must not be rejected by the PromQL parser just
+	// because they're nested under the "prometheus" section.
+	promConfigJSON := `{
+	  "prometheus": {
+	    "applications": [
+	      {
+	        "name": "default",
+	        "default": true,
+	        "dashboards": [
+	          {
+	            "groupKind": "pod",
+	            "tabs": ["GoldenSignal"],
+	            "providerType": "wavefront",
+	            "rows": [
+	              {
+	                "name": "container",
+	                "tab": "GoldenSignal",
+	                "graphs": [
+	                  {
+	                    "name": "container_cpu",
+	                    "graphType": "line",
+	                    "queryExpression": "ts(\"container.cpu.usage\", namespace=\"{{.namespace}}\")"
+	                  }
+	                ]
+	              }
+	            ]
+	          }
+	        ]
+	      }
+	    ],
+	    "provider": {
+	      "name": "default",
+	      "address": "http://prometheus-service.monitoring.svc.cluster.local:8080"
+	    }
+	  }
+	}`
+
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigs(logger, []byte(promConfigJSON))
+	require.NoError(t, err)
+
+	assert.NoError(t, config.Validate())
+}
+
+func TestO11yConfig_Validate_UnknownGraphType(t *testing.T) {
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigs(logger, []byte(testConfigJSON))
+	require.NoError(t, err)
+
+	config.Prometheus.Applications[0].Dashboards[0].Rows[0].Graphs[0].GraphType = "pie"
+
+	err = config.Validate()
+	require.Error(t, err)
+	validationErr, ok := err.(*ValidationErrors)
+	require.True(t, ok)
+	assert.Equal(t, "prometheus.applications[0].dashboards[0].rows[0].graphs[0].graphType", validationErr.Errors[0].Path)
+}
+
+func TestO11yConfig_Validate_InvalidPromQL(t *testing.T) {
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigs(logger, []byte(testConfigJSON))
+	require.NoError(t, err)
+
+	config.Prometheus.Applications[0].Dashboards[0].Rows[0].Graphs[0].QueryExpression = "sum(("
+
+	err = config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid PromQL expression")
+}
+
+func TestO11yConfig_Validate_EmptyQueryExpression(t *testing.T) {
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigs(logger, []byte(testConfigJSON))
+	require.NoError(t, err)
+
+	config.Prometheus.Applications[0].Dashboards[0].Rows[0].Graphs[0].QueryExpression = ""
+
+	err = config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "queryExpression must not be empty")
+}
+
+func TestO11yConfig_Validate_TabNotListed(t *testing.T) {
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigs(logger, []byte(testConfigJSON))
+	require.NoError(t, err)
+
+	config.Prometheus.Applications[0].Dashboards[0].Rows[0].Tab = "NotAListedTab"
+
+	err = config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `tab "NotAListedTab" is not listed`)
+}
+
+func TestO11yConfig_Validate_DuplicateGraphName(t *testing.T) {
+	logger := zap.NewExample().Sugar()
+	config, err := LoadConfigs(logger, []byte(testConfigJSON))
+	require.NoError(t, err)
+
+	row := config.Prometheus.Applications[0].Dashboards[0].Rows[1]
+	row.Graphs[1].Name = row.Graphs[0].Name
+
+	err = config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate graph name")
+}
+
+func TestLoadConfigs_StrictValidationRejectsBadConfig(t *testing.T) {
+	logger := zap.NewExample().Sugar()
+
+	badConfig := `{"prometheus": {"applications": [{"name": "demo", "dashboards": [{"groupKind": "pod", "rows": [{"name": "r", "graphs": [{"name": "g", "graphType": "pie", "queryExpression": "up"}]}]}]}]}}`
+
+	_, err := LoadConfigs(logger, []byte(badConfig), WithStrictValidation())
+	assert.Error(t, err)
+}
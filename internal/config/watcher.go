@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// ConfigWatcher watches a config file on disk and hot-reloads it into an
+// atomically-swapped O11yConfig snapshot, so HTTP handlers can pick up a
+// new config without the pod restarting.
+type ConfigWatcher struct {
+	path    string
+	logger  *zap.SugaredLogger
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+
+	current atomic.Pointer[O11yConfig]
+	version atomic.Uint64
+
+	mu          sync.Mutex
+	subscribers []chan *O11yConfig
+}
+
+// NewConfigWatcher loads path once to produce an initial snapshot, then
+// starts watching it for writes/renames and reloads on every change.
+func NewConfigWatcher(logger *zap.SugaredLogger, path string) (*ConfigWatcher, error) {
+	w := &ConfigWatcher{path: path, logger: logger, done: make(chan struct{})}
+
+	if err := w.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("creating fsnotify watcher: %w", err)
+	}
+	if err := watcher.Add(path); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("watching %s: %w", path, err)
+	}
+	w.watcher = watcher
+
+	go w.run()
+	return w, nil
+}
+
+func (w *ConfigWatcher) run() {
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+
+			// ConfigMap-mounted files are updated by swapping a symlink,
+			// which fsnotify reports as Remove/Rename rather than Write.
+			// Re-add the watch so we keep seeing future changes once the
+			// new target exists.
+			if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				if err := w.watcher.Add(w.path); err != nil {
+					w.logger.Errorw("error re-adding config watch", "path", w.path, "error", err)
+				}
+			}
+
+			if err := w.reload(); err != nil {
+				w.logger.Errorw("error reloading config", "path", w.path, "error", err)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Errorw("fsnotify error watching config", "path", w.path, "error", err)
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *ConfigWatcher) reload() error {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return fmt.Errorf("reading config file %s: %w", w.path, err)
+	}
+
+	conf, err := LoadConfigs(w.logger, data, WithStrictValidation())
+	if err != nil {
+		return err
+	}
+
+	conf.Version = w.version.Add(1)
+	w.current.Store(&conf)
+	w.notify(&conf)
+	return nil
+}
+
+// Snapshot returns the most recently loaded config. Safe to call
+// concurrently from any number of HTTP handler goroutines.
+func (w *ConfigWatcher) Snapshot() *O11yConfig {
+	return w.current.Load()
+}
+
+// Subscribe returns a channel that receives every new config snapshot as
+// it is loaded, so components that cache per-config state (e.g. provider
+// HTTP clients) can rebuild themselves. The channel is buffered with the
+// latest snapshot always winning, so a slow subscriber can miss
+// intermediate reloads but never blocks the watcher.
+func (w *ConfigWatcher) Subscribe() <-chan *O11yConfig {
+	ch := make(chan *O11yConfig, 1)
+
+	w.mu.Lock()
+	w.subscribers = append(w.subscribers, ch)
+	w.mu.Unlock()
+
+	return ch
+}
+
+func (w *ConfigWatcher) notify(conf *O11yConfig) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, ch := range w.subscribers {
+		select {
+		case ch <- conf:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- conf:
+			default:
+			}
+		}
+	}
+}
+
+// Close stops watching the config file. Subscriber channels are left open
+// so late readers can still drain their last buffered snapshot.
+func (w *ConfigWatcher) Close() error {
+	close(w.done)
+	return w.watcher.Close()
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestConfigWatcher_ReloadsOnWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(testConfigJSON), 0o644))
+
+	logger := zap.NewExample().Sugar()
+	watcher, err := NewConfigWatcher(logger, path)
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	initial := watcher.Snapshot()
+	require.NotNil(t, initial)
+	assert.Equal(t, uint64(1), initial.Version)
+	assert.Equal(t, "default", initial.Prometheus.Provider.Name)
+
+	sub := watcher.Subscribe()
+
+	updated := []byte(`{"prometheus": {"provider": {"name": "reloaded"}}}`)
+	require.NoError(t, os.WriteFile(path, updated, 0o644))
+
+	select {
+	case conf := <-sub:
+		assert.Equal(t, "reloaded", conf.Prometheus.Provider.Name)
+		assert.Equal(t, uint64(2), conf.Version)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for config reload")
+	}
+
+	assert.Equal(t, "reloaded", watcher.Snapshot().Prometheus.Provider.Name)
+}
+
+func TestConfigWatcher_RejectsInvalidReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(testConfigJSON), 0o644))
+
+	logger := zap.NewExample().Sugar()
+	watcher, err := NewConfigWatcher(logger, path)
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	require.NoError(t, watcher.reload())
+	goodVersion := watcher.Snapshot().Version
+
+	invalid := []byte(`{"prometheus": {"applications": [{"name": "demo", "dashboards": [{"groupKind": "pod", "rows": [{"name": "r", "graphs": [{"name": "g", "graphType": "pie", "queryExpression": "up"}]}]}]}]}}`)
+	require.NoError(t, os.WriteFile(path, invalid, 0o644))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if watcher.Snapshot().Version != goodVersion {
+			t.Fatal("invalid config must not have been swapped into the live snapshot")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	assert.Equal(t, "default", watcher.Snapshot().Prometheus.Provider.Name,
+		"snapshot should still be serving the last good config")
+}
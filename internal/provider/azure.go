@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mahanmmi/argocd-extension-metrics/internal/config"
+)
+
+const (
+	azureTokenRefreshSkew = 5 * time.Minute
+	imdsTokenEndpoint     = "http://169.254.169.254/metadata/identity/oauth2/token"
+	imdsAPIVersion        = "2018-02-01"
+)
+
+// AzureTokenSource acquires and caches AAD bearer tokens for Azure Managed
+// Prometheus, refreshing them ~5 minutes before expiry. It supports both
+// the managed identity (IMDS) and workload identity (federated token file)
+// flows, so the same binary works unmodified in either AKS setup.
+type AzureTokenSource struct {
+	cfg    config.AzureAuth
+	mode   string
+	client *http.Client
+
+	// aadEndpoint is the AAD v2 token endpoint template used for the
+	// workload identity flow. Overridable in tests; defaults to
+	// https://login.microsoftonline.com/%s/oauth2/v2.0/token.
+	aadEndpoint string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewAzureTokenSource builds an AzureTokenSource for the given AuthMode
+// (config.AuthModeAzureManagedIdentity or AuthModeAzureWorkloadIdentity).
+// If client is nil, http.DefaultClient is used.
+func NewAzureTokenSource(mode string, cfg config.AzureAuth, client *http.Client) *AzureTokenSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if cfg.Resource == "" {
+		cfg.Resource = config.DefaultAzureResource
+	}
+	return &AzureTokenSource{cfg: cfg, mode: mode, client: client}
+}
+
+// Token returns a cached bearer token, acquiring or refreshing it first if
+// it is missing or within azureTokenRefreshSkew of expiry.
+func (s *AzureTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Until(s.expiresAt) > azureTokenRefreshSkew {
+		return s.token, nil
+	}
+
+	var (
+		token     string
+		expiresIn time.Duration
+		err       error
+	)
+	if s.mode == config.AuthModeAzureWorkloadIdentity {
+		token, expiresIn, err = s.fetchWorkloadIdentityToken(ctx)
+	} else {
+		token, expiresIn, err = s.fetchManagedIdentityToken(ctx)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.expiresAt = time.Now().Add(expiresIn)
+	return s.token, nil
+}
+
+type azureTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   string `json:"expires_in"`
+}
+
+// fetchManagedIdentityToken acquires a token via IMDS, the flow used when
+// running on an AKS node with a managed identity attached.
+func (s *AzureTokenSource) fetchManagedIdentityToken(ctx context.Context) (string, time.Duration, error) {
+	q := url.Values{}
+	q.Set("api-version", imdsAPIVersion)
+	q.Set("resource", s.cfg.Resource)
+	if s.cfg.ClientID != "" {
+		q.Set("client_id", s.cfg.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imdsTokenEndpoint+"?"+q.Encode(), nil)
+	if err != nil {
+		return "", 0, fmt.Errorf("building IMDS request: %w", err)
+	}
+	req.Header.Set("Metadata", "true")
+
+	return s.doTokenRequest(req)
+}
+
+// fetchWorkloadIdentityToken exchanges the pod's projected federated token
+// file for an AAD access token, the flow used with AKS workload identity.
+func (s *AzureTokenSource) fetchWorkloadIdentityToken(ctx context.Context) (string, time.Duration, error) {
+	assertion, err := os.ReadFile(s.cfg.TokenFile)
+	if err != nil {
+		return "", 0, fmt.Errorf("reading federated token file: %w", err)
+	}
+
+	endpointTemplate := s.aadEndpoint
+	if endpointTemplate == "" {
+		endpointTemplate = "https://login.microsoftonline.com/%s/oauth2/v2.0/token"
+	}
+	tokenEndpoint := fmt.Sprintf(endpointTemplate, s.cfg.TenantID)
+
+	form := url.Values{}
+	form.Set("scope", s.cfg.Resource)
+	form.Set("client_id", s.cfg.ClientID)
+	form.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
+	form.Set("client_assertion", strings.TrimSpace(string(assertion)))
+	form.Set("grant_type", "client_credentials")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("building AAD token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return s.doTokenRequest(req)
+}
+
+func (s *AzureTokenSource) doTokenRequest(req *http.Request) (string, time.Duration, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("requesting AAD token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("AAD token request failed with status %s", resp.Status)
+	}
+
+	var tr azureTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return "", 0, fmt.Errorf("decoding AAD token response: %w", err)
+	}
+
+	seconds, err := strconv.Atoi(tr.ExpiresIn)
+	if err != nil {
+		seconds = 3600
+	}
+
+	return tr.AccessToken, time.Duration(seconds) * time.Second, nil
+}
+
+// azureAuthRoundTripper injects a fresh AAD bearer token from source into
+// every outgoing request's Authorization header.
+type azureAuthRoundTripper struct {
+	source *AzureTokenSource
+	next   http.RoundTripper
+}
+
+// NewAzureAuthRoundTripper wraps next (or http.DefaultTransport if nil)
+// with one that sets "Authorization: Bearer <token>" using source.
+func NewAzureAuthRoundTripper(source *AzureTokenSource, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &azureAuthRoundTripper{source: source, next: next}
+}
+
+func (rt *azureAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := rt.source.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("acquiring azure token: %w", err)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return rt.next.RoundTrip(req)
+}
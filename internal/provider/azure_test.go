@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Mahanmmi/argocd-extension-metrics/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAzureTokenSource_WorkloadIdentity(t *testing.T) {
+	tokenFile, err := os.CreateTemp(t.TempDir(), "federated-token")
+	require.NoError(t, err)
+	_, err = tokenFile.WriteString("federated-jwt")
+	require.NoError(t, err)
+	require.NoError(t, tokenFile.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "federated-jwt", r.FormValue("client_assertion"))
+		fmt.Fprint(w, `{"access_token": "fake-token", "expires_in": "3599"}`)
+	}))
+	defer server.Close()
+
+	source := NewAzureTokenSource(config.AuthModeAzureWorkloadIdentity, config.AzureAuth{
+		ClientID:  "client-id",
+		TenantID:  "tenant-id",
+		TokenFile: tokenFile.Name(),
+	}, server.Client())
+	source.aadEndpoint = server.URL + "/%s/oauth2/v2.0/token"
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "fake-token", token)
+
+	// Second call should hit the cache, not the server.
+	server.Close()
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "fake-token", token)
+}
+
+func TestAzureTokenSource_DefaultResource(t *testing.T) {
+	source := NewAzureTokenSource(config.AuthModeAzureManagedIdentity, config.AzureAuth{}, nil)
+	assert.Equal(t, config.DefaultAzureResource, source.cfg.Resource)
+}
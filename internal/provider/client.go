@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"net/http"
+
+	"github.com/Mahanmmi/argocd-extension-metrics/internal/config"
+)
+
+// NewHTTPClient builds the *http.Client actually used to query a
+// configured provider: cfg.NewHTTPClient()'s base client (TLS, basic
+// auth, bearer token, OAuth2 per HTTPClientConfig), wrapped with Azure AAD
+// bearer-token injection when cfg.AuthMode is one of the two Azure modes.
+// For every other AuthMode the base client is returned unwrapped.
+func NewHTTPClient(cfg config.ProviderConfig) (*http.Client, error) {
+	base, err := cfg.NewHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AuthMode != config.AuthModeAzureManagedIdentity && cfg.AuthMode != config.AuthModeAzureWorkloadIdentity {
+		return base, nil
+	}
+
+	source := NewAzureTokenSource(cfg.AuthMode, cfg.Azure, base)
+
+	azureClient := *base
+	azureClient.Transport = NewAzureAuthRoundTripper(source, base.Transport)
+	return &azureClient, nil
+}
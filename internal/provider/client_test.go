@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Mahanmmi/argocd-extension-metrics/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClient_AzureWorkloadIdentityInjectsBearerToken(t *testing.T) {
+	tokenFile, err := os.CreateTemp(t.TempDir(), "federated-token")
+	require.NoError(t, err)
+	_, err = tokenFile.WriteString("federated-jwt")
+	require.NoError(t, err)
+	require.NoError(t, tokenFile.Close())
+
+	var gotAuthHeader string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeader = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token": "e2e-token", "expires_in": "3600"}`)
+	}))
+	defer tokenServer.Close()
+
+	cfg := config.ProviderConfig{
+		Name:     "azure-prom",
+		Address:  apiServer.URL,
+		AuthMode: config.AuthModeAzureWorkloadIdentity,
+		Azure: config.AzureAuth{
+			ClientID:  "client-id",
+			TenantID:  "tenant-id",
+			TokenFile: tokenFile.Name(),
+		},
+	}
+
+	client, err := NewHTTPClient(cfg)
+	require.NoError(t, err)
+
+	// Point the token exchange at our fake AAD server instead of the real
+	// login.microsoftonline.com endpoint.
+	rt, ok := client.Transport.(*azureAuthRoundTripper)
+	require.True(t, ok, "expected NewHTTPClient to wrap the transport with azureAuthRoundTripper for azure-workload-identity")
+	rt.source.aadEndpoint = tokenServer.URL + "/%s/oauth2/v2.0/token"
+
+	resp, err := client.Get(apiServer.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "Bearer e2e-token", gotAuthHeader)
+}
+
+func TestNewHTTPClient_NoAzureAuthModeLeavesTransportUnwrapped(t *testing.T) {
+	cfg := config.ProviderConfig{Name: "plain-prom", Address: "http://example.invalid"}
+
+	client, err := NewHTTPClient(cfg)
+	require.NoError(t, err)
+
+	_, wrapped := client.Transport.(*azureAuthRoundTripper)
+	assert.False(t, wrapped)
+}
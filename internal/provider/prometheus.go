@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// PrometheusProvider queries a Prometheus-compatible (Prometheus, Thanos,
+// Cortex, ...) HTTP API using PromQL expressions as-is.
+type PrometheusProvider struct {
+	api promv1.API
+}
+
+// NewPrometheusProvider builds a PrometheusProvider that talks to address
+// using client. If client is nil, client_golang's own default client (on
+// api.DefaultRoundTripper) is used.
+func NewPrometheusProvider(address string, client *http.Client) (*PrometheusProvider, error) {
+	cfg := promapi.Config{Address: address}
+	if client != nil {
+		cfg.Client = client
+	}
+
+	c, err := promapi.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating prometheus client: %w", err)
+	}
+
+	return &PrometheusProvider{api: promv1.NewAPI(c)}, nil
+}
+
+func (p *PrometheusProvider) Query(ctx context.Context, expr string, r Range) ([]Series, error) {
+	value, warnings, err := p.api.QueryRange(ctx, expr, promv1.Range{
+		Start: r.Start,
+		End:   r.End,
+		Step:  r.Step,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying prometheus: %w", err)
+	}
+	_ = warnings
+
+	matrix, ok := value.(model.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("unexpected prometheus result type %T", value)
+	}
+
+	series := make([]Series, 0, len(matrix))
+	for _, sampleStream := range matrix {
+		labels := make(map[string]string, len(sampleStream.Metric))
+		for name, val := range sampleStream.Metric {
+			labels[string(name)] = string(val)
+		}
+
+		points := make([]Point, 0, len(sampleStream.Values))
+		for _, sample := range sampleStream.Values {
+			points = append(points, Point{
+				Timestamp: sample.Timestamp.Time(),
+				Value:     float64(sample.Value),
+			})
+		}
+
+		series = append(series, Series{Labels: labels, Points: points})
+	}
+
+	return series, nil
+}
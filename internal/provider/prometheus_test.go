@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusProvider_Query(t *testing.T) {
+	var gotQuery string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		gotQuery = r.FormValue("query")
+
+		fmt.Fprint(w, `{
+			"status": "success",
+			"data": {
+				"resultType": "matrix",
+				"result": [
+					{
+						"metric": {"pod_template_hash": "abc123"},
+						"values": [[1000, "1.5"], [1060, "2.5"]]
+					}
+				]
+			}
+		}`)
+	}))
+	defer server.Close()
+
+	p, err := NewPrometheusProvider(server.URL, server.Client())
+	require.NoError(t, err)
+
+	start := time.Unix(1000, 0)
+	end := time.Unix(2000, 0)
+	series, err := p.Query(context.Background(), "up", Range{Start: start, End: end, Step: time.Minute})
+	require.NoError(t, err)
+
+	assert.Equal(t, "up", gotQuery)
+
+	require.Len(t, series, 1)
+	assert.Equal(t, map[string]string{"pod_template_hash": "abc123"}, series[0].Labels)
+	require.Len(t, series[0].Points, 2)
+	assert.Equal(t, time.Unix(1000, 0), series[0].Points[0].Timestamp)
+	assert.Equal(t, 1.5, series[0].Points[0].Value)
+	assert.Equal(t, time.Unix(1060, 0), series[0].Points[1].Timestamp)
+	assert.Equal(t, 2.5, series[0].Points[1].Value)
+}
+
+func TestPrometheusProvider_Query_UnexpectedResultType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"status": "success",
+			"data": {
+				"resultType": "vector",
+				"result": [{"metric": {}, "value": [1000, "1"]}]
+			}
+		}`)
+	}))
+	defer server.Close()
+
+	p, err := NewPrometheusProvider(server.URL, server.Client())
+	require.NoError(t, err)
+
+	_, err = p.Query(context.Background(), "up", Range{Step: time.Minute})
+	assert.Error(t, err)
+}
+
+func TestPrometheusProvider_Query_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"status": "error", "errorType": "internal", "error": "boom"}`)
+	}))
+	defer server.Close()
+
+	p, err := NewPrometheusProvider(server.URL, server.Client())
+	require.NoError(t, err)
+
+	_, err = p.Query(context.Background(), "up", Range{Step: time.Minute})
+	assert.Error(t, err)
+}
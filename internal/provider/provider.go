@@ -0,0 +1,38 @@
+// Package provider abstracts away the metrics backend (Prometheus,
+// Wavefront, ...) behind a single query interface so the rest of the
+// extension can render a Graph without caring where its data comes from.
+package provider
+
+import (
+	"context"
+	"time"
+)
+
+// Point is a single sample of a time series.
+type Point struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// Series is a single named time series returned by a Provider query, keyed
+// by the label set that identifies it (e.g. {"pod_template_hash": "abc123"}).
+type Series struct {
+	Labels map[string]string
+	Points []Point
+}
+
+// Range describes the time window and resolution a Provider should query
+// over.
+type Range struct {
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+}
+
+// Provider queries a metrics backend for a query expression over a time
+// range and returns the resulting series. Implementations translate expr
+// (as written in Graph.QueryExpression) into whatever query language their
+// backend speaks.
+type Provider interface {
+	Query(ctx context.Context, expr string, r Range) ([]Series, error)
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mahanmmi/argocd-extension-metrics/internal/config"
+)
+
+// Provider type identifiers as used in Dashboard.ProviderType / Graph.ProviderType.
+const (
+	TypePrometheus = "prometheus"
+	TypeWavefront  = "wavefront"
+)
+
+// Router dispatches a Dashboard/Graph pair to the Provider configured for
+// it, falling back to Prometheus when no ProviderType is set.
+type Router struct {
+	providers map[string]Provider
+}
+
+// NewRouter builds a Router. prometheus must not be nil; wavefront may be
+// nil if no Wavefront provider is configured.
+func NewRouter(prometheus, wavefront Provider) *Router {
+	providers := map[string]Provider{
+		TypePrometheus: prometheus,
+	}
+	if wavefront != nil {
+		providers[TypeWavefront] = wavefront
+	}
+	return &Router{providers: providers}
+}
+
+// For resolves the Provider to use for a graph belonging to dash. A Graph's
+// own ProviderType takes precedence over its Dashboard's, and an unset
+// ProviderType falls back to Prometheus.
+func (r *Router) For(dash *config.Dashboard, graph *config.Graph) (Provider, error) {
+	providerType := dash.ProviderType
+	if graph.ProviderType != "" {
+		providerType = graph.ProviderType
+	}
+	if providerType == "" {
+		providerType = TypePrometheus
+	}
+	providerType = strings.ToLower(providerType)
+
+	p, ok := r.providers[providerType]
+	if !ok {
+		return nil, fmt.Errorf("no provider configured for providerType %q", providerType)
+	}
+	return p, nil
+}
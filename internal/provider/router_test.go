@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mahanmmi/argocd-extension-metrics/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider struct{ name string }
+
+func (f *fakeProvider) Query(ctx context.Context, expr string, r Range) ([]Series, error) {
+	return nil, nil
+}
+
+func TestRouter_For(t *testing.T) {
+	prom := &fakeProvider{name: "prom"}
+	wave := &fakeProvider{name: "wave"}
+	router := NewRouter(prom, wave)
+
+	testCases := []struct {
+		name     string
+		dash     *config.Dashboard
+		graph    *config.Graph
+		expected *fakeProvider
+	}{
+		{
+			name:     "no providerType falls back to prometheus",
+			dash:     &config.Dashboard{},
+			graph:    &config.Graph{},
+			expected: prom,
+		},
+		{
+			name:     "dashboard providerType wavefront",
+			dash:     &config.Dashboard{ProviderType: "wavefront"},
+			graph:    &config.Graph{},
+			expected: wave,
+		},
+		{
+			name:     "graph providerType overrides dashboard",
+			dash:     &config.Dashboard{ProviderType: "wavefront"},
+			graph:    &config.Graph{ProviderType: "prometheus"},
+			expected: prom,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := router.For(tc.dash, tc.graph)
+			require.NoError(t, err)
+			assert.Same(t, tc.expected, p)
+		})
+	}
+}
+
+func TestRouter_For_UnknownProviderType(t *testing.T) {
+	router := NewRouter(&fakeProvider{name: "prom"}, nil)
+
+	_, err := router.For(&config.Dashboard{ProviderType: "datadog"}, &config.Graph{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWavefrontProvider_Query(t *testing.T) {
+	var gotQuery, gotAuth, gotGranularity string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("q")
+		gotGranularity = r.URL.Query().Get("g")
+		gotAuth = r.Header.Get("Authorization")
+
+		fmt.Fprint(w, `{
+			"timeseries": [
+				{
+					"label": "cpu",
+					"tags": {"pod": "demo-pod"},
+					"data": [[1000, 1.5], [1060, 2.5]]
+				}
+			]
+		}`)
+	}))
+	defer server.Close()
+
+	p := NewWavefrontProvider(server.URL, "my-token", server.Client())
+
+	start := time.Unix(1000, 0)
+	end := time.Unix(2000, 0)
+	series, err := p.Query(context.Background(), `ts("cpu.usage")`, Range{Start: start, End: end, Step: time.Minute})
+	require.NoError(t, err)
+
+	assert.Equal(t, `ts("cpu.usage")`, gotQuery)
+	assert.Equal(t, "Bearer my-token", gotAuth)
+	assert.Equal(t, "m", gotGranularity)
+
+	require.Len(t, series, 1)
+	assert.Equal(t, map[string]string{"pod": "demo-pod"}, series[0].Labels)
+	require.Len(t, series[0].Points, 2)
+	assert.Equal(t, time.Unix(1000, 0), series[0].Points[0].Timestamp)
+	assert.Equal(t, 1.5, series[0].Points[0].Value)
+	assert.Equal(t, time.Unix(1060, 0), series[0].Points[1].Timestamp)
+	assert.Equal(t, 2.5, series[0].Points[1].Value)
+}
+
+func TestWavefrontProvider_Query_NonOKStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	p := NewWavefrontProvider(server.URL, "bad-token", server.Client())
+
+	_, err := p.Query(context.Background(), "ts(foo)", Range{Step: time.Minute})
+	assert.Error(t, err)
+}
+
+func TestWavefrontGranularity(t *testing.T) {
+	testCases := []struct {
+		step     time.Duration
+		expected string
+	}{
+		{step: 30 * time.Second, expected: "s"},
+		{step: 5 * time.Minute, expected: "m"},
+		{step: 6 * time.Hour, expected: "h"},
+		{step: 48 * time.Hour, expected: "d"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, wavefrontGranularity(tc.step))
+	}
+}